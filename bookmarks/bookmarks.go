@@ -0,0 +1,104 @@
+// Package bookmarks implements a small persisted bookmark list for the
+// browser, modeled on Bombadillo's bookmarks design: a pair of parallel
+// title/link slices plus the pane navigation state needed to drive a focused
+// list view.
+package bookmarks
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// Bookmarks holds the user's saved pages and the state of the bookmarks pane.
+type Bookmarks struct {
+	Titles    []string `json:"titles"`
+	Links     []string `json:"links"`
+	Position  int      `json:"-"`
+	IsFocused bool     `json:"-"`
+}
+
+// New returns an empty Bookmarks set.
+func New() *Bookmarks {
+	return &Bookmarks{
+		Titles: make([]string, 0),
+		Links:  make([]string, 0),
+	}
+}
+
+// Add appends a bookmark for title/link. Links already bookmarked are left
+// untouched rather than duplicated.
+func (b *Bookmarks) Add(title, link string) {
+	for _, existing := range b.Links {
+		if existing == link {
+			return
+		}
+	}
+	b.Titles = append(b.Titles, title)
+	b.Links = append(b.Links, link)
+}
+
+// Remove deletes the bookmark at index, clamping Position back into range.
+func (b *Bookmarks) Remove(index int) {
+	if index < 0 || index >= len(b.Links) {
+		return
+	}
+	b.Titles = append(b.Titles[:index], b.Titles[index+1:]...)
+	b.Links = append(b.Links[:index], b.Links[index+1:]...)
+	if b.Position >= len(b.Links) {
+		b.Position = len(b.Links) - 1
+	}
+}
+
+// List returns the bookmarked titles and links, in display order.
+func (b *Bookmarks) List() ([]string, []string) {
+	return b.Titles, b.Links
+}
+
+// configPath returns the path to the bookmarks file under the user's config
+// directory, e.g. ~/.config/go-web-viewer/bookmarks.json.
+func configPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-web-viewer", "bookmarks.json"), nil
+}
+
+// Save persists the bookmarks to disk, creating the config directory if
+// needed.
+func (b *Bookmarks) Save() error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Load reads the persisted bookmarks from disk. A missing file is not an
+// error; it yields an empty Bookmarks set ready to be saved later.
+func Load() (*Bookmarks, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return New(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	b := New()
+	if err := json.Unmarshal(data, b); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
@@ -0,0 +1,81 @@
+package bookmarks
+
+import "testing"
+
+func TestAddSkipsDuplicateLinks(t *testing.T) {
+	b := New()
+	b.Add("Example", "https://example.com")
+	b.Add("Example Again", "https://example.com")
+
+	titles, links := b.List()
+	if len(titles) != 1 || len(links) != 1 {
+		t.Fatalf("expected one bookmark, got titles=%v links=%v", titles, links)
+	}
+	if titles[0] != "Example" {
+		t.Fatalf("expected first Add to win, got title %q", titles[0])
+	}
+}
+
+func TestRemoveClampsPosition(t *testing.T) {
+	b := New()
+	b.Add("A", "https://a.example")
+	b.Add("B", "https://b.example")
+	b.Position = 1
+
+	b.Remove(1)
+
+	titles, links := b.List()
+	if len(titles) != 1 || titles[0] != "A" || links[0] != "https://a.example" {
+		t.Fatalf("unexpected bookmarks after Remove: titles=%v links=%v", titles, links)
+	}
+	if b.Position != 0 {
+		t.Fatalf("expected Position clamped to 0, got %d", b.Position)
+	}
+}
+
+func TestRemoveOutOfRangeIsNoOp(t *testing.T) {
+	b := New()
+	b.Add("A", "https://a.example")
+
+	b.Remove(5)
+	b.Remove(-1)
+
+	titles, _ := b.List()
+	if len(titles) != 1 {
+		t.Fatalf("expected out-of-range Remove to be a no-op, got %v", titles)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	b := New()
+	b.Add("Example", "https://example.com")
+	if err := b.Save(); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	titles, links := loaded.List()
+	if len(titles) != 1 || titles[0] != "Example" || links[0] != "https://example.com" {
+		t.Fatalf("unexpected bookmarks after round trip: titles=%v links=%v", titles, links)
+	}
+}
+
+func TestLoadMissingFileReturnsEmptySet(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	loaded, err := Load()
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	titles, links := loaded.List()
+	if len(titles) != 0 || len(links) != 0 {
+		t.Fatalf("expected empty bookmarks, got titles=%v links=%v", titles, links)
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// cacheEntry is the metadata pageCache persists alongside a cached body,
+// enough to issue a conditional revalidation request later.
+type cacheEntry struct {
+	MimeType     string    `json:"mime_type"`
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	FetchedAt    time.Time `json:"fetched_at"`
+}
+
+// pageCache is an on-disk store of fetched page bodies, keyed by a hash of
+// their URL, under ~/.cache/go-web-viewer/.
+type pageCache struct {
+	mu       sync.Mutex
+	dir      string
+	ttl      time.Duration
+	disabled bool
+}
+
+// newPageCache returns a pageCache using the user's cache directory. A
+// disabled cache (--no-cache) never reads or writes entries.
+func newPageCache(ttl time.Duration, disabled bool) *pageCache {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = ""
+	} else {
+		dir = filepath.Join(dir, "go-web-viewer")
+	}
+	return &pageCache{dir: dir, ttl: ttl, disabled: disabled}
+}
+
+// paths returns the metadata and body file paths for rawURL.
+func (c *pageCache) paths(rawURL string) (metaPath, bodyPath string) {
+	sum := sha256.Sum256([]byte(rawURL))
+	hash := hex.EncodeToString(sum[:])
+	return filepath.Join(c.dir, hash+".json"), filepath.Join(c.dir, hash+".body")
+}
+
+// load returns the cached entry and body for rawURL, if present and usable.
+func (c *pageCache) load(rawURL string) (*cacheEntry, []byte, bool) {
+	if c.disabled || c.dir == "" {
+		return nil, nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	metaPath, bodyPath := c.paths(rawURL)
+	meta, err := os.ReadFile(metaPath)
+	if err != nil {
+		return nil, nil, false
+	}
+	var entry cacheEntry
+	if err := json.Unmarshal(meta, &entry); err != nil {
+		return nil, nil, false
+	}
+	body, err := os.ReadFile(bodyPath)
+	if err != nil {
+		return nil, nil, false
+	}
+	return &entry, body, true
+}
+
+// fresh reports whether entry is still within the cache's TTL. A zero TTL
+// means entries are never served without revalidation.
+func (c *pageCache) fresh(entry *cacheEntry) bool {
+	return c.ttl > 0 && time.Since(entry.FetchedAt) < c.ttl
+}
+
+// store persists entry and body for rawURL, creating the cache directory if
+// needed. It is a no-op when the cache is disabled or unavailable.
+func (c *pageCache) store(rawURL string, entry cacheEntry, body []byte) error {
+	if c.disabled || c.dir == "" {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return err
+	}
+	metaPath, bodyPath := c.paths(rawURL)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(bodyPath, body, 0o644); err != nil {
+		return err
+	}
+	return os.WriteFile(metaPath, data, 0o644)
+}
+
+// docCacheEntry is one entry in the in-memory parsed-document LRU: a page's
+// already-rendered content plus the link/form/field tables Renderer.Render
+// populated alongside it, keyed by URL, so revisiting it via back/forward
+// doesn't re-fetch or re-parse and restores exactly the numbering the page
+// was rendered with.
+type docCacheEntry struct {
+	url     string
+	title   string
+	content string
+	links   map[int]string
+	forms   map[int]*formInfo
+	fields  map[int]*formField
+}
+
+// docCache is a small in-memory LRU of rendered pages shared across all
+// tabs, most-recently-used first.
+type docCache struct {
+	capacity int
+	entries  []docCacheEntry
+}
+
+// newDocCache returns an empty docCache holding up to capacity entries.
+func newDocCache(capacity int) *docCache {
+	return &docCache{capacity: capacity}
+}
+
+// get returns the cached render for url, promoting it to most-recently-used.
+// The returned links/forms/fields are copies, so a tab restored from them
+// (e.g. via "set N value") can't mutate the cached entry or another tab
+// restored from the same entry.
+func (c *docCache) get(url string) (docCacheEntry, bool) {
+	for i, e := range c.entries {
+		if e.url == url {
+			c.entries = append(c.entries[:i], c.entries[i+1:]...)
+			c.entries = append([]docCacheEntry{e}, c.entries...)
+			e.links = copyLinks(e.links)
+			e.forms = copyForms(e.forms)
+			e.fields = copyFields(e.fields)
+			return e, true
+		}
+	}
+	return docCacheEntry{}, false
+}
+
+// put inserts or refreshes url's rendered content, along with its links,
+// forms, and fields, as the most-recently-used entry, evicting the oldest
+// entry once over capacity. links/forms/fields are copied in, so later
+// mutations to the tab's own maps (e.g. "set N value") don't corrupt the
+// cached snapshot.
+func (c *docCache) put(url, title, content string, links map[int]string, forms map[int]*formInfo, fields map[int]*formField) {
+	for i, e := range c.entries {
+		if e.url == url {
+			c.entries = append(c.entries[:i], c.entries[i+1:]...)
+			break
+		}
+	}
+	entry := docCacheEntry{
+		url:     url,
+		title:   title,
+		content: content,
+		links:   copyLinks(links),
+		forms:   copyForms(forms),
+		fields:  copyFields(fields),
+	}
+	c.entries = append([]docCacheEntry{entry}, c.entries...)
+	if len(c.entries) > c.capacity {
+		c.entries = c.entries[:c.capacity]
+	}
+}
+
+// copyLinks returns an independent copy of a tab's link table.
+func copyLinks(links map[int]string) map[int]string {
+	out := make(map[int]string, len(links))
+	for k, v := range links {
+		out[k] = v
+	}
+	return out
+}
+
+// copyForms returns an independent copy of a tab's form table, including the
+// formInfo values it points to.
+func copyForms(forms map[int]*formInfo) map[int]*formInfo {
+	out := make(map[int]*formInfo, len(forms))
+	for k, v := range forms {
+		if v == nil {
+			continue
+		}
+		info := *v
+		info.fieldNums = append([]int(nil), v.fieldNums...)
+		out[k] = &info
+	}
+	return out
+}
+
+// copyFields returns an independent copy of a tab's field table, including
+// the formField values it points to.
+func copyFields(fields map[int]*formField) map[int]*formField {
+	out := make(map[int]*formField, len(fields))
+	for k, v := range fields {
+		if v == nil {
+			continue
+		}
+		field := *v
+		out[k] = &field
+	}
+	return out
+}
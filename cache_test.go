@@ -0,0 +1,116 @@
+package main
+
+import "testing"
+
+func TestDocCacheGetMiss(t *testing.T) {
+	c := newDocCache(2)
+	if _, ok := c.get("https://example.com"); ok {
+		t.Fatal("get on empty cache should miss")
+	}
+}
+
+func TestDocCacheRoundTripsLinksFormsFields(t *testing.T) {
+	c := newDocCache(2)
+	links := map[int]string{1: "https://example.com/a"}
+	forms := map[int]*formInfo{1: {action: "https://example.com/submit", method: "POST", fieldNums: []int{1}}}
+	fields := map[int]*formField{1: {name: "q", value: "hello"}}
+
+	c.put("https://example.com", "Example", "<content>", links, forms, fields)
+
+	entry, ok := c.get("https://example.com")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if entry.title != "Example" || entry.content != "<content>" {
+		t.Fatalf("unexpected entry: %+v", entry)
+	}
+	if entry.links[1] != "https://example.com/a" {
+		t.Fatalf("links not restored: %+v", entry.links)
+	}
+	if entry.forms[1] == nil || entry.forms[1].action != "https://example.com/submit" {
+		t.Fatalf("forms not restored: %+v", entry.forms)
+	}
+	if entry.fields[1] == nil || entry.fields[1].value != "hello" {
+		t.Fatalf("fields not restored: %+v", entry.fields)
+	}
+}
+
+func TestDocCacheIsolatesEntriesFromCallerMutation(t *testing.T) {
+	c := newDocCache(2)
+	fields := map[int]*formField{1: {name: "q", value: "hello"}}
+	c.put("https://example.com", "Example", "<content>", nil, nil, fields)
+
+	// Mutating the caller's field after put, as setField does on a live
+	// tab, must not reach into the cached snapshot.
+	fields[1].value = "mutated"
+
+	first, ok := c.get("https://example.com")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if first.fields[1].value != "hello" {
+		t.Fatalf("cached entry was corrupted by caller mutation: %+v", first.fields[1])
+	}
+
+	// Mutating one restored tab's fields must not bleed into a second tab
+	// restored from the same cache entry.
+	first.fields[1].value = "tab one edit"
+	second, ok := c.get("https://example.com")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if second.fields[1].value != "hello" {
+		t.Fatalf("restoring a second tab saw the first tab's edit: %+v", second.fields[1])
+	}
+}
+
+func TestDocCacheEvictsLeastRecentlyUsed(t *testing.T) {
+	c := newDocCache(2)
+	c.put("https://a.example", "A", "a", nil, nil, nil)
+	c.put("https://b.example", "B", "b", nil, nil, nil)
+	c.put("https://c.example", "C", "c", nil, nil, nil)
+
+	if _, ok := c.get("https://a.example"); ok {
+		t.Fatal("oldest entry should have been evicted")
+	}
+	if _, ok := c.get("https://b.example"); !ok {
+		t.Fatal("b should still be cached")
+	}
+	if _, ok := c.get("https://c.example"); !ok {
+		t.Fatal("c should still be cached")
+	}
+}
+
+func TestDocCacheGetPromotesToMostRecentlyUsed(t *testing.T) {
+	c := newDocCache(2)
+	c.put("https://a.example", "A", "a", nil, nil, nil)
+	c.put("https://b.example", "B", "b", nil, nil, nil)
+
+	// Touch a so it becomes most-recently-used, ahead of b.
+	c.get("https://a.example")
+	c.put("https://c.example", "C", "c", nil, nil, nil)
+
+	if _, ok := c.get("https://b.example"); ok {
+		t.Fatal("b should have been evicted after a was promoted")
+	}
+	if _, ok := c.get("https://a.example"); !ok {
+		t.Fatal("a should still be cached")
+	}
+}
+
+func TestDocCachePutRefreshesExistingEntry(t *testing.T) {
+	c := newDocCache(2)
+	c.put("https://a.example", "A", "old", nil, nil, nil)
+	c.put("https://a.example", "A", "new", nil, nil, nil)
+
+	entry, ok := c.get("https://a.example")
+	if !ok {
+		t.Fatal("expected cache hit")
+	}
+	if entry.content != "new" {
+		t.Fatalf("expected refreshed content, got %q", entry.content)
+	}
+	if len(c.entries) != 1 {
+		t.Fatalf("expected a single entry after refresh, got %d", len(c.entries))
+	}
+}
@@ -0,0 +1,176 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Fetcher retrieves the raw content behind a URL, reporting its MIME type so
+// the right Renderer can be chosen.
+type Fetcher interface {
+	Fetch(url string) (mimeType string, body io.ReadCloser, err error)
+}
+
+// fetchers maps a URL scheme to the Fetcher that handles it. registerHTTPFetcher
+// replaces the http/https entries once the session's client and cache are ready.
+var fetchers = map[string]Fetcher{
+	"http":   httpFetcher{client: http.DefaultClient},
+	"https":  httpFetcher{client: http.DefaultClient},
+	"file":   fileFetcher{},
+	"gemini": geminiFetcher{},
+}
+
+// registerHTTPFetcher points the http/https schemes at a Fetcher using
+// client and cache, so every caller (TUI session, headless server) shares
+// the same on-disk cache.
+func registerHTTPFetcher(client *http.Client, cache *pageCache) {
+	hf := httpFetcher{client: client, cache: cache}
+	fetchers["http"] = hf
+	fetchers["https"] = hf
+}
+
+// isSupportedScheme reports whether scheme has a registered Fetcher.
+func isSupportedScheme(scheme string) bool {
+	_, ok := fetchers[scheme]
+	return ok
+}
+
+// fetch dispatches rawURL to the Fetcher registered for its scheme.
+func fetch(rawURL string) (string, io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", nil, err
+	}
+	f, ok := fetchers[u.Scheme]
+	if !ok {
+		return "", nil, fmt.Errorf("unsupported scheme: %s", u.Scheme)
+	}
+	return f.Fetch(rawURL)
+}
+
+// fetchFresh dispatches rawURL like fetch, but tells an httpFetcher to
+// bypass its cache entirely, for the ":reload" command.
+func fetchFresh(rawURL string) (string, io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", nil, err
+	}
+	if hf, ok := fetchers[u.Scheme].(httpFetcher); ok {
+		return hf.fetch(rawURL, false)
+	}
+	return fetch(rawURL)
+}
+
+// httpFetcher handles http:// and https:// URLs via its client, optionally
+// caching responses on disk and revalidating them with conditional requests.
+type httpFetcher struct {
+	client *http.Client
+	cache  *pageCache
+}
+
+func (hf httpFetcher) Fetch(rawURL string) (string, io.ReadCloser, error) {
+	return hf.fetch(rawURL, true)
+}
+
+// fetch performs the request, consulting and updating hf.cache when useCache
+// is true.
+func (hf httpFetcher) fetch(rawURL string, useCache bool) (string, io.ReadCloser, error) {
+	var cached *cacheEntry
+	var cachedBody []byte
+	if hf.cache != nil {
+		if entry, body, ok := hf.cache.load(rawURL); ok {
+			if useCache && hf.cache.fresh(entry) {
+				return entry.MimeType, io.NopCloser(bytes.NewReader(body)), nil
+			}
+			cached, cachedBody = entry, body
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, rawURL, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	if useCache && cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+
+	resp, err := hf.client.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		cached.FetchedAt = time.Now()
+		hf.cache.store(rawURL, *cached, cachedBody)
+		return cached.MimeType, io.NopCloser(bytes.NewReader(cachedBody)), nil
+	}
+	if resp.StatusCode >= 400 {
+		return "", nil, fmt.Errorf("http status %s", resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", nil, err
+	}
+
+	mimeType := resp.Header.Get("Content-Type")
+	if idx := strings.Index(mimeType, ";"); idx != -1 {
+		mimeType = mimeType[:idx]
+	}
+	mimeType = strings.TrimSpace(mimeType)
+	if mimeType == "" {
+		mimeType = "text/html"
+	}
+
+	if hf.cache != nil {
+		hf.cache.store(rawURL, cacheEntry{
+			MimeType:     mimeType,
+			ETag:         resp.Header.Get("ETag"),
+			LastModified: resp.Header.Get("Last-Modified"),
+			FetchedAt:    time.Now(),
+		}, data)
+	}
+
+	return mimeType, io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// fileFetcher handles file:// URLs, reading straight off local disk.
+type fileFetcher struct{}
+
+func (fileFetcher) Fetch(rawURL string) (string, io.ReadCloser, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", nil, err
+	}
+	f, err := os.Open(u.Path)
+	if err != nil {
+		return "", nil, err
+	}
+	return mimeForPath(u.Path), f, nil
+}
+
+// mimeForPath guesses a MIME type from a local file's extension, falling
+// back to text/html for anything unrecognized.
+func mimeForPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".gmi", ".gemini":
+		return "text/gemini"
+	case ".txt":
+		return "text/plain"
+	default:
+		return "text/html"
+	}
+}
@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// maxGeminiRedirects bounds the 3x-redirect chain fetchGemini will follow.
+const maxGeminiRedirects = 5
+
+// geminiInputMimeType is the synthetic MIME type fetchGemini reports for a
+// 1x response, so fetchAndDisplay can recognize it and arm the tab to
+// answer the prompt with its next typed input, and gemtextInputRenderer can
+// render it distinctly from an ordinary gemtext page.
+const geminiInputMimeType = "application/x-gemini-input"
+
+// geminiFetcher handles gemini:// URLs: TLS with trust-on-first-use
+// certificate pinning, Gemini's single request-line/response-header
+// protocol, and 3x redirects.
+type geminiFetcher struct{}
+
+func (geminiFetcher) Fetch(rawURL string) (string, io.ReadCloser, error) {
+	return fetchGemini(rawURL, 0)
+}
+
+// fetchGemini performs one Gemini request, following redirects up to
+// maxGeminiRedirects.
+func fetchGemini(rawURL string, redirectsFollowed int) (string, io.ReadCloser, error) {
+	if redirectsFollowed > maxGeminiRedirects {
+		return "", nil, fmt.Errorf("too many gemini redirects")
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", nil, err
+	}
+	host := u.Host
+	if !strings.Contains(host, ":") {
+		host += ":1965"
+	}
+
+	// Gemini has no CA-verified PKI; trust is established per-host on first
+	// contact and pinned in known_hosts instead.
+	conn, err := tls.Dial("tcp", host, &tls.Config{InsecureSkipVerify: true})
+	if err != nil {
+		return "", nil, err
+	}
+	if err := verifyGeminiCert(u.Hostname(), conn); err != nil {
+		conn.Close()
+		return "", nil, err
+	}
+
+	if _, err := fmt.Fprintf(conn, "%s\r\n", rawURL); err != nil {
+		conn.Close()
+		return "", nil, err
+	}
+
+	reader := bufio.NewReader(conn)
+	statusLine, err := reader.ReadString('\n')
+	if err != nil {
+		conn.Close()
+		return "", nil, err
+	}
+	statusLine = strings.TrimRight(statusLine, "\r\n")
+
+	code, meta, err := parseGeminiStatus(statusLine)
+	if err != nil {
+		conn.Close()
+		return "", nil, err
+	}
+
+	switch {
+	case code >= 10 && code < 20:
+		// Input requested: report the prompt under a sentinel MIME type so
+		// the caller can arm the tab to answer it (see answerGeminiInput),
+		// rather than blocking here for a second round-trip.
+		conn.Close()
+		return geminiInputMimeType, io.NopCloser(strings.NewReader(meta)), nil
+	case code >= 20 && code < 30:
+		return meta, readCloser{Reader: reader, closer: conn}, nil
+	case code >= 30 && code < 40:
+		conn.Close()
+		target := meta
+		if resolved, err := u.Parse(meta); err == nil {
+			target = resolved.String()
+		}
+		return fetchGemini(target, redirectsFollowed+1)
+	default:
+		conn.Close()
+		return "", nil, fmt.Errorf("gemini error %d: %s", code, meta)
+	}
+}
+
+// geminiInputURL builds the request URL that answers a 1x input prompt
+// previously requested from promptURL: the same URL with its query set to
+// the percent-encoded answer, per the Gemini spec.
+func geminiInputURL(promptURL, answer string) string {
+	u, err := url.Parse(promptURL)
+	if err != nil {
+		return promptURL
+	}
+	u.RawQuery = strings.ReplaceAll(url.QueryEscape(answer), "+", "%20")
+	return u.String()
+}
+
+// parseGeminiStatus splits a Gemini response header into its status code and
+// meta string.
+func parseGeminiStatus(statusLine string) (int, string, error) {
+	parts := strings.SplitN(statusLine, " ", 2)
+	code, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, "", fmt.Errorf("malformed gemini response: %q", statusLine)
+	}
+	meta := ""
+	if len(parts) > 1 {
+		meta = parts[1]
+	}
+	return code, meta, nil
+}
+
+// readCloser pairs a Reader already positioned mid-stream with the Closer
+// that owns the underlying connection.
+type readCloser struct {
+	io.Reader
+	closer io.Closer
+}
+
+func (rc readCloser) Close() error {
+	return rc.closer.Close()
+}
+
+// verifyGeminiCert implements trust-on-first-use: the first certificate seen
+// for a host is pinned to ~/.config/go-web-viewer/known_hosts, and later
+// connections must match it.
+func verifyGeminiCert(host string, conn *tls.Conn) error {
+	state := conn.ConnectionState()
+	if len(state.PeerCertificates) == 0 {
+		return fmt.Errorf("no certificate presented by %s", host)
+	}
+	fingerprint := sha256.Sum256(state.PeerCertificates[0].Raw)
+	hexFingerprint := hex.EncodeToString(fingerprint[:])
+
+	known, err := loadKnownHosts()
+	if err != nil {
+		return err
+	}
+	if existing, ok := known[host]; ok {
+		if existing != hexFingerprint {
+			return fmt.Errorf("certificate for %s changed (expected %s, got %s); refusing to connect", host, existing, hexFingerprint)
+		}
+		return nil
+	}
+	known[host] = hexFingerprint
+	return saveKnownHosts(known)
+}
+
+// knownHostsPath returns ~/.config/go-web-viewer/known_hosts.
+func knownHostsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-web-viewer", "known_hosts"), nil
+}
+
+// loadKnownHosts reads the pinned host->fingerprint table. A missing file is
+// not an error; it yields an empty table.
+func loadKnownHosts() (map[string]string, error) {
+	known := make(map[string]string)
+	path, err := knownHostsPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return known, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) != 2 {
+			continue
+		}
+		known[fields[0]] = fields[1]
+	}
+	return known, nil
+}
+
+// saveKnownHosts writes the pinned host->fingerprint table back to disk.
+func saveKnownHosts(known map[string]string) error {
+	path, err := knownHostsPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	var sb strings.Builder
+	for host, fingerprint := range known {
+		fmt.Fprintf(&sb, "%s %s\n", host, fingerprint)
+	}
+	return os.WriteFile(path, []byte(sb.String()), 0o644)
+}
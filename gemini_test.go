@@ -0,0 +1,63 @@
+package main
+
+import "testing"
+
+func TestParseGeminiStatusWithMeta(t *testing.T) {
+	code, meta, err := parseGeminiStatus("20 text/gemini")
+	if err != nil {
+		t.Fatalf("parseGeminiStatus() error = %v", err)
+	}
+	if code != 20 || meta != "text/gemini" {
+		t.Fatalf("parseGeminiStatus() = (%d, %q), want (20, %q)", code, meta, "text/gemini")
+	}
+}
+
+func TestParseGeminiStatusWithoutMeta(t *testing.T) {
+	code, meta, err := parseGeminiStatus("51")
+	if err != nil {
+		t.Fatalf("parseGeminiStatus() error = %v", err)
+	}
+	if code != 51 || meta != "" {
+		t.Fatalf("parseGeminiStatus() = (%d, %q), want (51, \"\")", code, meta)
+	}
+}
+
+func TestParseGeminiStatusMalformed(t *testing.T) {
+	if _, _, err := parseGeminiStatus("not a status line"); err == nil {
+		t.Fatal("expected an error for a malformed status line")
+	}
+}
+
+func TestGeminiInputURLSetsEncodedQuery(t *testing.T) {
+	got := geminiInputURL("gemini://example.com/search", "hello world")
+	want := "gemini://example.com/search?hello%20world"
+	if got != want {
+		t.Fatalf("geminiInputURL() = %q, want %q", got, want)
+	}
+}
+
+func TestKnownHostsSaveLoadRoundTrip(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	known, err := loadKnownHosts()
+	if err != nil {
+		t.Fatalf("loadKnownHosts() error = %v", err)
+	}
+	if len(known) != 0 {
+		t.Fatalf("expected no known hosts yet, got %v", known)
+	}
+
+	known["example.com"] = "deadbeef"
+	if err := saveKnownHosts(known); err != nil {
+		t.Fatalf("saveKnownHosts() error = %v", err)
+	}
+
+	reloaded, err := loadKnownHosts()
+	if err != nil {
+		t.Fatalf("loadKnownHosts() error = %v", err)
+	}
+	if reloaded["example.com"] != "deadbeef" {
+		t.Fatalf("reloaded known hosts = %v, want fingerprint pinned for example.com", reloaded)
+	}
+}
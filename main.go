@@ -1,42 +1,178 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/Ate329/go-web-viewer/bookmarks"
 	"github.com/gdamore/tcell/v2"
 	"github.com/rivo/tview"
 	"golang.org/x/net/html"
 )
 
+// aboutBookmarks is the special URL that renders the bookmarks list into
+// pageView instead of fetching a page.
+const aboutBookmarks = "about:bookmarks"
+
+// formField is one <input>/<select>/<textarea> collected from a <form>,
+// keyed by its own number so "set N value" commands can address it.
+//
+// This is a deliberate deviation from a "real" focusable-input form widget:
+// pageView is a tview.TextView, which has no native editable field regions,
+// so fields and forms are numbered and rendered as text (see collectForm in
+// render.go) and edited/submitted through "set N value" / "submit N" typed
+// into urlInput instead of being tabbed between and typed into directly.
+type formField struct {
+	name  string // The field's name= attribute, used as the submitted key
+	value string // The field's current value, editable via "set N value"
+}
+
+// formInfo is one <form> collected from the page, keyed by its own number so
+// "submit N" commands can address it.
+type formInfo struct {
+	action    string // Resolved absolute URL the form submits to
+	method    string // "GET" or "POST"
+	fieldNums []int  // Numbers of the fields belonging to this form, in order
+}
+
+// Tab represents a single browser tab, with its own URL, scroll position,
+// rendered content, and independent back/forward history stack.
+type Tab struct {
+	title           string             // Page title, shown in the tab bar
+	currentURL      string             // URL currently displayed in this tab
+	content         string             // Last rendered content (with tview color tags) for this tab
+	scrollRow       int                // Last scroll offset within pageView for this tab
+	history         []string           // Stack of visited URLs for this tab
+	historyPos      int                // Index into history of the currently displayed URL
+	links           map[int]string     // Numeric link references visible on the current page
+	forms           map[int]*formInfo  // Numbered forms visible on the current page
+	fields          map[int]*formField // Numbered form fields visible on the current page
+	pendingInputURL string             // Gemini URL awaiting an answer to a 1x input prompt, if any
+}
+
+// NewTab creates a new, empty Tab with no history.
+func NewTab() *Tab {
+	return &Tab{
+		history:    make([]string, 0),
+		historyPos: -1,
+		links:      make(map[int]string),
+		forms:      make(map[int]*formInfo),
+		fields:     make(map[int]*formField),
+	}
+}
+
+// canGoBack reports whether the tab has an earlier history entry.
+func (t *Tab) canGoBack() bool {
+	return t.historyPos > 0
+}
+
+// canGoForward reports whether the tab has a later history entry.
+func (t *Tab) canGoForward() bool {
+	return t.historyPos < len(t.history)-1
+}
+
+// visit records url as the tab's current location, truncating any forward
+// history so a fresh navigation replaces the abandoned branch.
+func (t *Tab) visit(url string) {
+	t.history = append(t.history[:t.historyPos+1], url)
+	t.historyPos = len(t.history) - 1
+	t.currentURL = url
+}
+
 // Browser represents the main application structure
 type Browser struct {
-	app        *tview.Application // The main application
-	pageView   *tview.TextView    // Displays the webpage content
-	urlInput   *tview.InputField  // Input field for entering URLs
-	statusBar  *tview.TextView    // Displays status messages
-	history    []string           // Stores browsing history
-	currentURL string             // Current URL being displayed
+	app              *tview.Application   // The main application
+	tabBar           *tview.TextView      // Displays the open tabs
+	pageView         *tview.TextView      // Displays the webpage content
+	urlInput         *tview.InputField    // Input field for entering URLs
+	statusBar        *tview.TextView      // Displays status messages
+	tabs             []*Tab               // Open tabs
+	activeTab        int                  // Index of the currently focused tab within tabs
+	contentArea      *tview.Flex          // Holds pageView and, when open, bookmarksPane side by side
+	bookmarks        *bookmarks.Bookmarks // Persisted bookmarks
+	bookmarksPane    *tview.List          // Side pane listing bookmarks
+	bookmarksVisible bool                 // Whether bookmarksPane is currently shown
+	httpClient       *http.Client         // Shared HTTP client carrying cookies across this session
+	docCache         *docCache            // In-memory LRU of rendered pages, shared across all tabs
 }
 
-// NewBrowser creates and initializes a new Browser instance
-func NewBrowser() *Browser {
+// docCacheCapacity bounds how many rendered pages NewBrowser keeps in memory
+// for instant back/forward navigation.
+const docCacheCapacity = 20
+
+// NewBrowser creates and initializes a new Browser instance, fetching
+// http(s) pages through cache.
+func NewBrowser(cache *pageCache) *Browser {
+	bm, err := bookmarks.Load()
+	if err != nil {
+		bm = bookmarks.New()
+	}
+
+	jar, _ := cookiejar.New(nil)
+	httpClient := &http.Client{Jar: jar}
+
 	// Initialize a new Browser struct with its components
 	b := &Browser{
-		app:       tview.NewApplication(),
-		pageView:  tview.NewTextView().SetDynamicColors(true).SetRegions(true).SetWrap(true).SetScrollable(true),
-		urlInput:  tview.NewInputField().SetLabel("URL: "),
-		statusBar: tview.NewTextView().SetTextAlign(tview.AlignCenter),
-		history:   make([]string, 0),
+		app:           tview.NewApplication(),
+		tabBar:        tview.NewTextView().SetDynamicColors(true),
+		pageView:      tview.NewTextView().SetDynamicColors(true).SetRegions(true).SetWrap(true).SetScrollable(true),
+		urlInput:      tview.NewInputField().SetLabel("URL: "),
+		statusBar:     tview.NewTextView().SetTextAlign(tview.AlignCenter),
+		tabs:          []*Tab{NewTab()},
+		activeTab:     0,
+		bookmarks:     bm,
+		bookmarksPane: tview.NewList().ShowSecondaryText(false),
+		httpClient:    httpClient,
+		docCache:      newDocCache(docCacheCapacity),
 	}
+	b.bookmarksPane.SetBorder(true).SetTitle("Bookmarks")
 
-	// Set up the URL input field to handle Enter key press
+	// The shared client carries cookies for every HTTP(S) request this
+	// session makes, including plain page loads, and its cache persists
+	// fetched bodies across restarts.
+	registerHTTPFetcher(httpClient, cache)
+
+	// Set up the URL input field to handle Enter key press. A plain number
+	// is treated as a link reference; "set N value" edits a form field,
+	// "submit N" submits a form, and ":reload" bypasses the cache, all
+	// addressed by the numbers displayContent assigned.
 	b.urlInput.SetDoneFunc(func(key tcell.Key) {
 		if key == tcell.KeyEnter {
-			url := b.urlInput.GetText()
-			b.loadURL(url)
+			text := strings.TrimSpace(b.urlInput.GetText())
+			if text == ":reload" {
+				b.reloadTab()
+				return
+			}
+			if b.activeTabPtr().pendingInputURL != "" {
+				b.answerGeminiInput(text)
+				return
+			}
+			if linkNum, err := strconv.Atoi(text); err == nil {
+				b.followLink(linkNum)
+				return
+			}
+			if fields := strings.Fields(text); len(fields) >= 2 {
+				switch fields[0] {
+				case "submit":
+					if n, err := strconv.Atoi(fields[1]); err == nil {
+						b.submitForm(n)
+						return
+					}
+				case "set":
+					if n, err := strconv.Atoi(fields[1]); err == nil && len(fields) >= 3 {
+						b.setField(n, strings.Join(fields[2:], " "))
+						return
+					}
+				}
+			}
+			b.loadURL(text)
 		}
 	})
 
@@ -65,99 +201,445 @@ func NewBrowser() *Browser {
 		return event
 	})
 
+	// Global keybindings for tab and history management
+	b.app.SetInputCapture(b.handleGlobalKeys)
+
 	// Set color scheme for the browser components
 	b.urlInput.SetFieldBackgroundColor(tcell.ColorWhite)
 	b.urlInput.SetFieldTextColor(tcell.ColorBlack)
+	b.tabBar.SetBackgroundColor(tcell.ColorDarkGray)
+	b.tabBar.SetTextColor(tcell.ColorWhite)
 	b.pageView.SetBackgroundColor(tcell.ColorBlack)
 	b.pageView.SetTextColor(tcell.ColorWhite)
 	b.statusBar.SetBackgroundColor(tcell.ColorDarkGray)
 	b.statusBar.SetTextColor(tcell.ColorWhite)
 
+	b.renderTabBar()
+
 	return b
 }
 
-// loadURL fetches and displays the content of the given URL
+// handleGlobalKeys implements the tab and history keybindings: Ctrl-T opens a
+// new tab, Ctrl-W closes the active tab, Ctrl-Tab/Ctrl-Shift-Tab cycle
+// through tabs, and Alt-Left/Alt-Right move through the active tab's history.
+func (b *Browser) handleGlobalKeys(event *tcell.EventKey) *tcell.EventKey {
+	switch {
+	case event.Key() == tcell.KeyCtrlT:
+		b.newTab()
+		return nil
+	case event.Key() == tcell.KeyCtrlW:
+		b.closeTab(b.activeTab)
+		return nil
+	case event.Key() == tcell.KeyTAB && event.Modifiers()&tcell.ModCtrl != 0:
+		b.switchTab(b.activeTab + 1)
+		return nil
+	case event.Key() == tcell.KeyBacktab && event.Modifiers()&tcell.ModCtrl != 0:
+		b.switchTab(b.activeTab - 1)
+		return nil
+	case event.Key() == tcell.KeyLeft && event.Modifiers()&tcell.ModAlt != 0:
+		b.goBack()
+		return nil
+	case event.Key() == tcell.KeyRight && event.Modifiers()&tcell.ModAlt != 0:
+		b.goForward()
+		return nil
+	case event.Key() == tcell.KeyCtrlD:
+		b.addBookmark()
+		return nil
+	case event.Key() == tcell.KeyCtrlB:
+		b.toggleBookmarksPane()
+		return nil
+	}
+	return event
+}
+
+// activeTabPtr returns the Tab currently displayed in pageView.
+func (b *Browser) activeTabPtr() *Tab {
+	return b.tabs[b.activeTab]
+}
+
+// newTab opens a new, empty tab and switches focus to it.
+func (b *Browser) newTab() {
+	b.saveTabState()
+	b.tabs = append(b.tabs, NewTab())
+	b.activeTab = len(b.tabs) - 1
+	b.restoreTabState()
+	b.urlInput.SetText("")
+	b.statusBar.SetText("New tab")
+	b.renderTabBar()
+}
+
+// closeTab closes the tab at index, switching focus to a neighboring tab. The
+// last remaining tab cannot be closed.
+func (b *Browser) closeTab(index int) {
+	if len(b.tabs) <= 1 {
+		b.statusBar.SetText("Cannot close the last tab")
+		return
+	}
+	b.tabs = append(b.tabs[:index], b.tabs[index+1:]...)
+	if b.activeTab >= len(b.tabs) {
+		b.activeTab = len(b.tabs) - 1
+	}
+	b.restoreTabState()
+	b.renderTabBar()
+}
+
+// switchTab moves focus to the tab at index, wrapping around at either end.
+func (b *Browser) switchTab(index int) {
+	b.saveTabState()
+	b.activeTab = (index + len(b.tabs)) % len(b.tabs)
+	b.restoreTabState()
+	b.renderTabBar()
+}
+
+// saveTabState snapshots the currently displayed URL and scroll offset back
+// into the active tab before switching away from it.
+func (b *Browser) saveTabState() {
+	tab := b.activeTabPtr()
+	row, _ := b.pageView.GetScrollOffset()
+	tab.scrollRow = row
+}
+
+// restoreTabState redraws pageView and urlInput from the newly active tab.
+func (b *Browser) restoreTabState() {
+	tab := b.activeTabPtr()
+	b.urlInput.SetText(tab.currentURL)
+	b.pageView.SetText(tab.content)
+	b.pageView.ScrollTo(tab.scrollRow, 0)
+}
+
+// renderTabBar redraws the tab bar, highlighting the active tab.
+func (b *Browser) renderTabBar() {
+	var bar strings.Builder
+	for i, tab := range b.tabs {
+		label := tab.title
+		if label == "" {
+			label = tab.currentURL
+		}
+		if label == "" {
+			label = "new tab"
+		}
+		if i == b.activeTab {
+			bar.WriteString(fmt.Sprintf(" [black:white] %d: %s [-:-]", i+1, label))
+		} else {
+			bar.WriteString(fmt.Sprintf(" %d: %s ", i+1, label))
+		}
+	}
+	b.tabBar.SetText(bar.String())
+}
+
+// goBack navigates the active tab to the previous entry in its history.
+func (b *Browser) goBack() {
+	tab := b.activeTabPtr()
+	if !tab.canGoBack() {
+		b.statusBar.SetText("No earlier page in history")
+		return
+	}
+	tab.historyPos--
+	b.fetchAndDisplay(tab, tab.history[tab.historyPos])
+}
+
+// goForward navigates the active tab to the next entry in its history.
+func (b *Browser) goForward() {
+	tab := b.activeTabPtr()
+	if !tab.canGoForward() {
+		b.statusBar.SetText("No later page in history")
+		return
+	}
+	tab.historyPos++
+	b.fetchAndDisplay(tab, tab.history[tab.historyPos])
+}
+
+// loadURL fetches and displays the given URL in the active tab, recording it
+// as a new entry in that tab's history.
 func (b *Browser) loadURL(url string) {
-	b.statusBar.SetText("Loading...")
-	content, err := fetchContent(processURL(url))
-	if err != nil {
-		b.statusBar.SetText(fmt.Sprintf("Error: %v", err))
+	tab := b.activeTabPtr()
+	if url == aboutBookmarks {
+		tab.visit(url)
+	} else {
+		tab.visit(processURL(url))
+	}
+	b.fetchAndDisplay(tab, tab.currentURL)
+}
+
+// addBookmark saves the active tab's current page under its title.
+func (b *Browser) addBookmark() {
+	tab := b.activeTabPtr()
+	if tab.currentURL == "" || tab.currentURL == aboutBookmarks {
+		b.statusBar.SetText("Nothing to bookmark")
 		return
 	}
-	b.currentURL = url
-	b.history = append(b.history, url)
-	b.displayContent(content)
-	b.statusBar.SetText("Loaded: " + url)
+	title := tab.title
+	if title == "" {
+		title = tab.currentURL
+	}
+	b.bookmarks.Add(title, tab.currentURL)
+	if err := b.bookmarks.Save(); err != nil {
+		b.statusBar.SetText(fmt.Sprintf("Error saving bookmarks: %v", err))
+		return
+	}
+	b.statusBar.SetText("Bookmarked: " + tab.currentURL)
+}
+
+// toggleBookmarksPane shows or hides the bookmarks side pane next to
+// pageView.
+func (b *Browser) toggleBookmarksPane() {
+	if b.bookmarksVisible {
+		b.contentArea.RemoveItem(b.bookmarksPane)
+		b.bookmarksVisible = false
+		b.app.SetFocus(b.urlInput)
+		return
+	}
+	b.refreshBookmarksPane()
+	b.contentArea.AddItem(b.bookmarksPane, 30, 0, true)
+	b.bookmarksVisible = true
+	b.app.SetFocus(b.bookmarksPane)
+}
+
+// refreshBookmarksPane repopulates the bookmarks list from the persisted
+// Bookmarks set and wires up navigation (Enter) and deletion ('d').
+func (b *Browser) refreshBookmarksPane() {
+	b.bookmarksPane.Clear()
+	titles, links := b.bookmarks.List()
+	for i, title := range titles {
+		link := links[i]
+		b.bookmarksPane.AddItem(title, "", 0, func() {
+			b.loadURL(link)
+		})
+	}
+	b.bookmarksPane.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Rune() == 'd' {
+			b.bookmarks.Remove(b.bookmarksPane.GetCurrentItem())
+			if err := b.bookmarks.Save(); err != nil {
+				b.statusBar.SetText(fmt.Sprintf("Error saving bookmarks: %v", err))
+			}
+			b.refreshBookmarksPane()
+			return nil
+		}
+		return event
+	})
+}
+
+// showBookmarksPage renders the bookmarks list into pageView as numbered,
+// clickable links, for the about:bookmarks URL.
+func (b *Browser) showBookmarksPage(tab *Tab) {
+	titles, links := b.bookmarks.List()
+
+	var sb strings.Builder
+	sb.WriteString("[green::b]Bookmarks[-::-]\n\n")
+	if len(titles) == 0 {
+		sb.WriteString("No bookmarks yet. Press Ctrl-D on a page to add one.\n")
+	}
+
+	tab.links = make(map[int]string)
+	for i, title := range titles {
+		tab.links[i+1] = links[i]
+		sb.WriteString(fmt.Sprintf("[%d][blue::u]%s[-::-]\n", i+1, title))
+	}
+
+	tab.currentURL = aboutBookmarks
+	tab.title = "Bookmarks"
+	tab.content = tview.TranslateANSI(sb.String())
+	tab.scrollRow = 0
+	tab.pendingInputURL = ""
+	b.urlInput.SetText(tab.currentURL)
+	b.pageView.SetText(tab.content)
+	b.pageView.ScrollToBeginning()
+	b.statusBar.SetText("Loaded: " + aboutBookmarks)
+	b.renderTabBar()
 }
 
-// processURL ensures the URL has a proper scheme (http:// or https://)
-func processURL(url string) string {
-	if !strings.HasPrefix(url, "https://") && !strings.HasPrefix(url, "http://") {
-		return "https://" + url
+// followLink navigates the active tab to the link previously assigned number
+// n by displayContent, resolved against the page it appeared on.
+func (b *Browser) followLink(n int) {
+	tab := b.activeTabPtr()
+	target, ok := tab.links[n]
+	if !ok {
+		b.statusBar.SetText(fmt.Sprintf("No link numbered %d", n))
+		return
 	}
-	return url
+	tab.visit(target)
+	b.fetchAndDisplay(tab, tab.currentURL)
+}
+
+// answerGeminiInput replies to the active tab's pending Gemini 1x input
+// prompt with answer, re-requesting the prompting URL with answer as its
+// query string, as the Gemini spec requires.
+func (b *Browser) answerGeminiInput(answer string) {
+	tab := b.activeTabPtr()
+	target := geminiInputURL(tab.pendingInputURL, answer)
+	tab.pendingInputURL = ""
+	tab.visit(target)
+	b.fetchAndDisplay(tab, tab.currentURL)
 }
 
-// fetchContent retrieves the content of a webpage
-func fetchContent(url string) (string, error) {
-	// Send an HTTP GET request to the URL
-	resp, err := http.Get(url)
+// setField updates the value of the form field previously assigned number n
+// by displayContent, ready to be picked up by a later "submit N".
+func (b *Browser) setField(n int, value string) {
+	tab := b.activeTabPtr()
+	f, ok := tab.fields[n]
+	if !ok {
+		b.statusBar.SetText(fmt.Sprintf("No field numbered %d", n))
+		return
+	}
+	f.value = value
+	b.statusBar.SetText(fmt.Sprintf("Set field %d (%s) = %q", n, f.name, value))
+}
+
+// submitForm builds the request body for the form previously assigned number
+// n by displayContent from its fields' current values, sends it with the
+// session's shared httpClient, and displays the response.
+func (b *Browser) submitForm(n int) {
+	tab := b.activeTabPtr()
+	form, ok := tab.forms[n]
+	if !ok {
+		b.statusBar.SetText(fmt.Sprintf("No form numbered %d", n))
+		return
+	}
+
+	values := url.Values{}
+	for _, fieldNum := range form.fieldNums {
+		f := tab.fields[fieldNum]
+		values.Set(f.name, f.value)
+	}
+
+	b.statusBar.SetText("Submitting...")
+	var resp *http.Response
+	var err error
+	if form.method == "POST" {
+		resp, err = b.httpClient.PostForm(form.action, values)
+	} else {
+		target := form.action
+		if u, parseErr := url.Parse(form.action); parseErr == nil {
+			u.RawQuery = values.Encode()
+			target = u.String()
+		}
+		resp, err = b.httpClient.Get(target)
+	}
 	if err != nil {
-		return "", err
+		b.statusBar.SetText(fmt.Sprintf("Error: %v", err))
+		return
 	}
 	defer resp.Body.Close()
 
-	// Read the response body
-	body, err := io.ReadAll(resp.Body)
+	mimeType := resp.Header.Get("Content-Type")
+	if idx := strings.Index(mimeType, ";"); idx != -1 {
+		mimeType = mimeType[:idx]
+	}
+	mimeType = strings.TrimSpace(mimeType)
+	if mimeType == "" {
+		mimeType = "text/html"
+	}
+
+	tab.visit(resp.Request.URL.String())
+	b.urlInput.SetText(tab.currentURL)
+	b.displayContent(tab, tab.currentURL, mimeType, resp.Body)
+	b.statusBar.SetText(fmt.Sprintf("Submitted form %d", n))
+	b.renderTabBar()
+}
+
+// fetchAndDisplay loads url into tab without touching its history stack, used
+// for both fresh navigation and back/forward replays. A hit in b.docCache,
+// shared across every tab, redisplays the page instantly without re-fetching
+// or re-parsing it.
+func (b *Browser) fetchAndDisplay(tab *Tab, url string) {
+	if url == aboutBookmarks {
+		b.showBookmarksPage(tab)
+		return
+	}
+	if entry, ok := b.docCache.get(url); ok {
+		tab.currentURL = url
+		tab.title = entry.title
+		tab.content = entry.content
+		tab.scrollRow = 0
+		tab.links = entry.links
+		tab.forms = entry.forms
+		tab.fields = entry.fields
+		tab.pendingInputURL = ""
+		b.urlInput.SetText(url)
+		b.pageView.SetText(tab.content)
+		b.pageView.ScrollToBeginning()
+		b.statusBar.SetText("Loaded: " + url)
+		b.renderTabBar()
+		return
+	}
+	b.statusBar.SetText("Loading...")
+	mimeType, body, err := fetch(url)
 	if err != nil {
-		return "", err
+		b.statusBar.SetText(fmt.Sprintf("Error: %v", err))
+		return
+	}
+	defer body.Close()
+	tab.currentURL = url
+	b.urlInput.SetText(url)
+	b.displayContent(tab, url, mimeType, body)
+	// An input prompt is only meaningful to the tab that's waiting on it, so
+	// it's never cached like an ordinary page.
+	if mimeType != geminiInputMimeType {
+		b.docCache.put(url, tab.title, tab.content, tab.links, tab.forms, tab.fields)
 	}
-	return string(body), nil
+	b.statusBar.SetText("Loaded: " + url)
+	b.renderTabBar()
 }
 
-// displayContent parses and displays the HTML content
-func (b *Browser) displayContent(content string) {
-	// Parse the HTML content
-	doc, err := html.Parse(strings.NewReader(content))
+// reloadTab re-fetches the active tab's current page, bypassing both the
+// in-memory doc cache and the on-disk page cache, for the ":reload" command.
+func (b *Browser) reloadTab() {
+	tab := b.activeTabPtr()
+	if tab.currentURL == "" || tab.currentURL == aboutBookmarks {
+		b.statusBar.SetText("Nothing to reload")
+		return
+	}
+	b.statusBar.SetText("Reloading...")
+	mimeType, body, err := fetchFresh(tab.currentURL)
 	if err != nil {
-		b.pageView.SetText(fmt.Sprintf("Error parsing HTML: %v", err))
-		return
-	}
-
-	var displayText strings.Builder
-	var title string
-	var inBody bool
-
-	// Define a recursive function to traverse the HTML tree
-	var traverse func(*html.Node)
-	traverse = func(n *html.Node) {
-		if n.Type == html.ElementNode {
-			switch n.Data {
-			case "title":
-				title = extractText(n)
-			case "body":
-				inBody = true
-			case "h1", "h2", "h3", "h4", "h5", "h6":
-				if inBody {
-					displayText.WriteString(fmt.Sprintf("\n[yellow::b]%s[-::-]\n", extractText(n)))
-				}
-			case "p":
-				if inBody {
-					displayText.WriteString(fmt.Sprintf("\n%s\n", extractText(n)))
-				}
-			}
-		}
-		for c := n.FirstChild; c != nil; c = c.NextSibling {
-			traverse(c)
-		}
+		b.statusBar.SetText(fmt.Sprintf("Error: %v", err))
+		return
 	}
+	defer body.Close()
+	b.displayContent(tab, tab.currentURL, mimeType, body)
+	if mimeType != geminiInputMimeType {
+		b.docCache.put(tab.currentURL, tab.title, tab.content, tab.links, tab.forms, tab.fields)
+	}
+	b.statusBar.SetText("Reloaded: " + tab.currentURL)
+	b.renderTabBar()
+}
 
-	// Traverse the HTML tree
-	traverse(doc)
+// processURL leaves URLs with a scheme this browser knows how to fetch
+// untouched, and otherwise treats the input as a bare host for https://.
+func processURL(raw string) string {
+	if strings.HasPrefix(raw, "about:") {
+		return raw
+	}
+	if u, err := url.Parse(raw); err == nil && isSupportedScheme(u.Scheme) {
+		return raw
+	}
+	return "https://" + raw
+}
+
+// displayContent renders a fetched page's body into tab and pageView,
+// dispatching to the Renderer registered for mimeType. baseURL is the page's
+// own URL, used to resolve relative links.
+func (b *Browser) displayContent(tab *Tab, baseURL, mimeType string, body io.Reader) {
+	base, _ := url.Parse(baseURL)
 
-	// Format and display the final text
-	finalText := fmt.Sprintf("[green::b]Title: %s[-::-]\n\n%s", title, displayText.String())
-	b.pageView.SetText(tview.TranslateANSI(finalText))
+	title, rendered, err := rendererFor(mimeType).Render(tab, base, body)
+	if err != nil {
+		tab.content = fmt.Sprintf("Error rendering page: %v", err)
+		b.pageView.SetText(tab.content)
+		return
+	}
+
+	if mimeType == geminiInputMimeType {
+		tab.pendingInputURL = baseURL
+	} else {
+		tab.pendingInputURL = ""
+	}
+
+	finalText := fmt.Sprintf("[green::b]Title: %s[-::-]\n\n%s", title, rendered)
+	tab.title = title
+	tab.content = tview.TranslateANSI(finalText)
+	tab.scrollRow = 0
+	b.pageView.SetText(tab.content)
 	b.pageView.ScrollToBeginning()
 }
 
@@ -174,24 +656,108 @@ func extractText(n *html.Node) string {
 	return strings.TrimSpace(text)
 }
 
+// renderInline walks n's children like extractText, but additionally turns
+// <a href> descendants into numbered, clickable link references: each anchor
+// is assigned the next link number, recorded in tab.links against its
+// resolved absolute URL, and rendered inline as "[N]link text".
+func renderInline(n *html.Node, tab *Tab, base *url.URL, linkNum *int) string {
+	var text strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		switch c.Type {
+		case html.TextNode:
+			text.WriteString(c.Data)
+		case html.ElementNode:
+			if c.Data == "a" {
+				linkText := strings.TrimSpace(renderInline(c, tab, base, linkNum))
+				href := getAttr(c, "href")
+				if href != "" && linkText != "" {
+					*linkNum++
+					tab.links[*linkNum] = resolveURL(base, href)
+					text.WriteString(fmt.Sprintf("[%d][blue::u]%s[-::-]", *linkNum, linkText))
+				} else {
+					text.WriteString(linkText)
+				}
+			} else {
+				text.WriteString(renderInline(c, tab, base, linkNum))
+			}
+		}
+	}
+	return strings.TrimSpace(text.String())
+}
+
+// getAttr returns the value of the named attribute on n, or "" if absent.
+func getAttr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// hasAttr reports whether n carries the named attribute at all, for
+// boolean attributes such as selected whose presence is what matters.
+func hasAttr(n *html.Node, key string) bool {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveURL resolves href against base, returning href unchanged if it
+// cannot be parsed or base is unknown.
+func resolveURL(base *url.URL, href string) string {
+	ref, err := url.Parse(href)
+	if err != nil {
+		return href
+	}
+	if base == nil {
+		return ref.String()
+	}
+	return base.ResolveReference(ref).String()
+}
+
 // Run starts the browser application
 func (b *Browser) Run() error {
+	// pageView lives in contentArea so the bookmarks pane can be toggled in
+	// and out alongside it without rebuilding the grid.
+	b.contentArea = tview.NewFlex().SetDirection(tview.FlexColumn).
+		AddItem(b.pageView, 0, 1, false)
+
 	// Create a grid layout for the browser UI
 	grid := tview.NewGrid().
-		SetRows(1, 0, 1).
+		SetRows(1, 1, 0, 1).
 		SetColumns(0).
 		SetBorders(true).
-		AddItem(b.urlInput, 0, 0, 1, 1, 0, 0, true).
-		AddItem(b.pageView, 1, 0, 1, 1, 0, 0, false).
-		AddItem(b.statusBar, 2, 0, 1, 1, 0, 0, false)
+		AddItem(b.tabBar, 0, 0, 1, 1, 0, 0, false).
+		AddItem(b.urlInput, 1, 0, 1, 1, 0, 0, true).
+		AddItem(b.contentArea, 2, 0, 1, 1, 0, 0, false).
+		AddItem(b.statusBar, 3, 0, 1, 1, 0, 0, false)
 
 	// Set the root of the application and run it
 	return b.app.SetRoot(grid, true).Run()
 }
 
 func main() {
+	httpServerAddr := flag.String("http-server", "", "run a headless text-rendering HTTP server on this address (e.g. :8080) instead of the TUI")
+	noCache := flag.Bool("no-cache", false, "disable the on-disk response cache")
+	cacheTTL := flag.Duration("cache-ttl", 10*time.Minute, "how long a cached response is served without revalidation")
+	flag.Parse()
+
+	cache := newPageCache(*cacheTTL, *noCache)
+
+	if *httpServerAddr != "" {
+		registerHTTPFetcher(http.DefaultClient, cache)
+		if err := runHTTPServer(*httpServerAddr); err != nil {
+			fmt.Printf("Error running HTTP server: %v\n", err)
+		}
+		return
+	}
+
 	// Create a new Browser instance and run it
-	browser := NewBrowser()
+	browser := NewBrowser(cache)
 	if err := browser.Run(); err != nil {
 		fmt.Printf("Error running browser: %v\n", err)
 	}
@@ -0,0 +1,258 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	"github.com/rivo/tview"
+	"golang.org/x/net/html"
+)
+
+// Renderer turns a fetched page body into tview markup, registering any
+// clickable links it finds on tab and reporting the page's title (if any).
+type Renderer interface {
+	Render(tab *Tab, base *url.URL, body io.Reader) (title, rendered string, err error)
+}
+
+// renderers maps a MIME type (as reported by a Fetcher) to the Renderer that
+// understands it.
+var renderers = map[string]Renderer{
+	"text/html":         htmlRenderer{},
+	"text/gemini":       gemtextRenderer{},
+	"text/plain":        plainRenderer{},
+	geminiInputMimeType: geminiInputRenderer{},
+}
+
+// rendererFor returns the Renderer registered for mimeType, falling back to
+// plain text for anything unrecognized.
+func rendererFor(mimeType string) Renderer {
+	if r, ok := renderers[mimeType]; ok {
+		return r
+	}
+	return plainRenderer{}
+}
+
+// htmlRenderer renders text/html, extracting headings, paragraphs, and
+// numbered links exactly as the original single-protocol browser did.
+type htmlRenderer struct{}
+
+func (htmlRenderer) Render(tab *Tab, base *url.URL, body io.Reader) (string, string, error) {
+	doc, err := html.Parse(body)
+	if err != nil {
+		return "", "", err
+	}
+
+	tab.links = make(map[int]string)
+	tab.forms = make(map[int]*formInfo)
+	tab.fields = make(map[int]*formField)
+	linkNum := 0
+	formNum := 0
+	fieldNum := 0
+
+	var displayText strings.Builder
+	var title string
+	var inBody bool
+
+	var traverse func(*html.Node)
+	traverse = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				title = extractText(n)
+			case "body":
+				inBody = true
+			case "h1", "h2", "h3", "h4", "h5", "h6":
+				if inBody {
+					displayText.WriteString(fmt.Sprintf("\n[yellow::b]%s[-::-]\n", renderInline(n, tab, base, &linkNum)))
+				}
+			case "p":
+				if inBody {
+					displayText.WriteString(fmt.Sprintf("\n%s\n", renderInline(n, tab, base, &linkNum)))
+				}
+			case "form":
+				if inBody {
+					formNum++
+					info := collectForm(n, tab, base, &fieldNum)
+					tab.forms[formNum] = info
+					displayText.WriteString(fmt.Sprintf("\n[purple::b][Form %d] %s %s[-::-]\n", formNum, info.method, info.action))
+					for _, fieldNum := range info.fieldNums {
+						f := tab.fields[fieldNum]
+						displayText.WriteString(fmt.Sprintf("  [%d] %s = %q\n", fieldNum, f.name, f.value))
+					}
+				}
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			traverse(c)
+		}
+	}
+	traverse(doc)
+
+	return title, displayText.String(), nil
+}
+
+// collectForm resolves n's action/method and walks its descendants for
+// input/select/textarea fields, numbering each field with *fieldNum so
+// "set N value" and "submit N" commands can address them later.
+func collectForm(n *html.Node, tab *Tab, base *url.URL, fieldNum *int) *formInfo {
+	method := strings.ToUpper(getAttr(n, "method"))
+	if method != "POST" {
+		method = "GET"
+	}
+	info := &formInfo{
+		action: resolveURL(base, getAttr(n, "action")),
+		method: method,
+	}
+
+	var walk func(*html.Node)
+	walk = func(c *html.Node) {
+		if c.Type == html.ElementNode {
+			if name := getAttr(c, "name"); name != "" {
+				var value string
+				switch c.Data {
+				case "input":
+					value = getAttr(c, "value")
+				case "textarea":
+					value = extractText(c)
+				case "select":
+					value = selectedOption(c)
+				}
+				if c.Data == "input" || c.Data == "textarea" || c.Data == "select" {
+					*fieldNum++
+					tab.fields[*fieldNum] = &formField{name: name, value: value}
+					info.fieldNums = append(info.fieldNums, *fieldNum)
+				}
+			}
+		}
+		for gc := c.FirstChild; gc != nil; gc = gc.NextSibling {
+			walk(gc)
+		}
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		walk(c)
+	}
+
+	return info
+}
+
+// selectedOption returns the value of a <select>'s selected <option>,
+// falling back to the first option when none is marked selected.
+func selectedOption(selectNode *html.Node) string {
+	var first string
+	for c := selectNode.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode || c.Data != "option" {
+			continue
+		}
+		value := getAttr(c, "value")
+		if value == "" {
+			value = extractText(c)
+		}
+		if first == "" {
+			first = value
+		}
+		if hasAttr(c, "selected") {
+			return value
+		}
+	}
+	return first
+}
+
+// gemtextRenderer renders text/gemini, the line-oriented format served by
+// Gemini: headings, quotes, list items, preformatted blocks, and "=>" links.
+type gemtextRenderer struct{}
+
+func (gemtextRenderer) Render(tab *Tab, base *url.URL, body io.Reader) (string, string, error) {
+	tab.links = make(map[int]string)
+	tab.forms = make(map[int]*formInfo)
+	tab.fields = make(map[int]*formField)
+	linkNum := 0
+	inPre := false
+
+	var out strings.Builder
+	var title string
+
+	scanner := bufio.NewScanner(body)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case strings.HasPrefix(line, "```"):
+			inPre = !inPre
+		case inPre:
+			out.WriteString(line + "\n")
+		case strings.HasPrefix(line, "=>"):
+			target, label := parseGemtextLink(line)
+			linkNum++
+			tab.links[linkNum] = resolveURL(base, target)
+			out.WriteString(fmt.Sprintf("[%d][blue::u]%s[-::-]\n", linkNum, label))
+		case strings.HasPrefix(line, "#"):
+			text := strings.TrimLeft(line, "#")
+			text = strings.TrimSpace(text)
+			out.WriteString(fmt.Sprintf("[yellow::b]%s[-::-]\n", text))
+			if title == "" {
+				title = text
+			}
+		case strings.HasPrefix(line, ">"):
+			out.WriteString(fmt.Sprintf("[gray::i]%s[-::-]\n", strings.TrimSpace(strings.TrimPrefix(line, ">"))))
+		case strings.HasPrefix(line, "* "):
+			out.WriteString("  - " + strings.TrimPrefix(line, "* ") + "\n")
+		default:
+			out.WriteString(line + "\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", "", err
+	}
+
+	return title, out.String(), nil
+}
+
+// parseGemtextLink splits a gemtext "=> target label" line into its target
+// and display label, falling back to the target itself when no label is
+// given.
+func parseGemtextLink(line string) (target, label string) {
+	rest := strings.TrimSpace(strings.TrimPrefix(line, "=>"))
+	fields := strings.SplitN(rest, " ", 2)
+	target = fields[0]
+	label = target
+	if len(fields) > 1 {
+		label = strings.TrimSpace(fields[1])
+	}
+	return target, label
+}
+
+// plainRenderer renders text/plain verbatim, escaping tview's own markup
+// syntax so stray "[" characters in the source don't get interpreted as
+// color tags.
+type plainRenderer struct{}
+
+func (plainRenderer) Render(tab *Tab, base *url.URL, body io.Reader) (string, string, error) {
+	tab.links = make(map[int]string)
+	tab.forms = make(map[int]*formInfo)
+	tab.fields = make(map[int]*formField)
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return "", "", err
+	}
+	return "", tview.Escape(string(data)), nil
+}
+
+// geminiInputRenderer renders a Gemini 1x response's prompt, telling the
+// user to type their answer into urlInput and press Enter, which
+// answerGeminiInput (armed by fetchAndDisplay) turns into the reply request.
+type geminiInputRenderer struct{}
+
+func (geminiInputRenderer) Render(tab *Tab, base *url.URL, body io.Reader) (string, string, error) {
+	tab.links = make(map[int]string)
+	tab.forms = make(map[int]*formInfo)
+	tab.fields = make(map[int]*formField)
+	prompt, err := io.ReadAll(body)
+	if err != nil {
+		return "", "", err
+	}
+	rendered := fmt.Sprintf("[purple::b]Input requested:[-::-] %s\n\nType your response and press Enter.", tview.Escape(string(prompt)))
+	return "Input requested", rendered, nil
+}
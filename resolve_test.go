@@ -0,0 +1,47 @@
+package main
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestResolveURLRelativeAgainstBase(t *testing.T) {
+	base, err := url.Parse("https://example.com/dir/page.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := resolveURL(base, "other.html")
+	want := "https://example.com/dir/other.html"
+	if got != want {
+		t.Fatalf("resolveURL() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveURLAbsoluteHrefIgnoresBase(t *testing.T) {
+	base, err := url.Parse("https://example.com/dir/page.html")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := resolveURL(base, "https://other.example/x")
+	want := "https://other.example/x"
+	if got != want {
+		t.Fatalf("resolveURL() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveURLNilBaseReturnsHrefUnchanged(t *testing.T) {
+	got := resolveURL(nil, "gemini://example.com/page.gmi")
+	want := "gemini://example.com/page.gmi"
+	if got != want {
+		t.Fatalf("resolveURL() = %q, want %q", got, want)
+	}
+}
+
+func TestResolveURLUnparseableHrefReturnsUnchanged(t *testing.T) {
+	base, _ := url.Parse("https://example.com/")
+	got := resolveURL(base, "http://[::1")
+	want := "http://[::1"
+	if got != want {
+		t.Fatalf("resolveURL() = %q, want %q", got, want)
+	}
+}
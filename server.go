@@ -0,0 +1,165 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+// renderResult is the JSON body returned when a caller sends
+// Accept: application/json.
+type renderResult struct {
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	LoadMs  int64  `json:"load_ms"`
+	ParseMs int64  `json:"parse_ms"`
+}
+
+// knownStyleTags are the tview color tags this browser's renderers emit.
+// Stripping exactly these (and nothing else) leaves numbered link markers
+// like "[1]" intact in headless output.
+var knownStyleTags = []string{
+	"[green::b]", "[yellow::b]", "[blue::u]", "[purple::b]", "[gray::i]", "[-::-]",
+}
+
+// stripMarkup removes this browser's tview color tags from rendered text,
+// leaving plain text suitable for a raw-text HTTP response.
+func stripMarkup(s string) string {
+	for _, tag := range knownStyleTags {
+		s = strings.ReplaceAll(s, tag, "")
+	}
+	return s
+}
+
+// runHTTPServer starts the headless raw-text server: it fetches and renders
+// whatever URL a request names, through the same Fetcher/Renderer pipeline
+// the TUI uses, and writes back plain text (or JSON, on request).
+func runHTTPServer(addr string) error {
+	limiter := newRateLimiter(30, time.Minute)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if !limiter.allow(clientIP(r)) {
+			http.Error(w, "rate limit exceeded", http.StatusTooManyRequests)
+			return
+		}
+		handleRenderRequest(w, r)
+	})
+
+	fmt.Printf("go-web-viewer: listening on %s\n", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// handleRenderRequest fetches and renders the URL named by the request path
+// or its ?url= query parameter.
+func handleRenderRequest(w http.ResponseWriter, r *http.Request) {
+	target := r.URL.Query().Get("url")
+	if target == "" {
+		target = strings.TrimPrefix(r.URL.Path, "/")
+	}
+	if target == "" {
+		http.Error(w, "missing url: pass it as the path or a ?url= query parameter", http.StatusBadRequest)
+		return
+	}
+	target = processURL(target)
+
+	loadStart := time.Now()
+	mimeType, body, err := fetch(target)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadGateway)
+		return
+	}
+	defer body.Close()
+	loadMs := time.Since(loadStart).Milliseconds()
+
+	base, _ := url.Parse(target)
+	tab := NewTab()
+
+	parseStart := time.Now()
+	title, rendered, err := rendererFor(mimeType).Render(tab, base, body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	parseMs := time.Since(parseStart).Milliseconds()
+
+	// rendered may contain both real style tags (stripped by stripMarkup)
+	// and literal brackets from the source escaped by tview.Escape for the
+	// TUI's tag parser to undo on display; Unescape does the same for this
+	// headless path, which never goes through a tview.TextView.
+	plainBody := tview.Unescape(stripMarkup(rendered))
+
+	if strings.Contains(r.Header.Get("Accept"), "application/json") {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(renderResult{
+			Title:   title,
+			Body:    plainBody,
+			LoadMs:  loadMs,
+			ParseMs: parseMs,
+		})
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	fmt.Fprintf(w, "Title: %s\n\n%s", title, plainBody)
+}
+
+// clientIP returns the request's remote address with any port stripped, for
+// use as a rate limiter key.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// rateLimiter is a fixed-window limiter keyed by client IP, enough to stop a
+// single misbehaving script from hammering the sites this server fetches on
+// its callers' behalf.
+type rateLimiter struct {
+	mu       sync.Mutex
+	limit    int
+	window   time.Duration
+	requests map[string][]time.Time
+}
+
+// newRateLimiter returns a rateLimiter allowing up to limit requests per key
+// within window.
+func newRateLimiter(limit int, window time.Duration) *rateLimiter {
+	return &rateLimiter{
+		limit:    limit,
+		window:   window,
+		requests: make(map[string][]time.Time),
+	}
+}
+
+// allow reports whether key may make another request now, recording it if
+// so and discarding any of its requests that have aged out of window.
+func (rl *rateLimiter) allow(key string) bool {
+	now := time.Now()
+	cutoff := now.Add(-rl.window)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	kept := rl.requests[key][:0]
+	for _, t := range rl.requests[key] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+	if len(kept) >= rl.limit {
+		rl.requests[key] = kept
+		return false
+	}
+	rl.requests[key] = append(kept, now)
+	return true
+}
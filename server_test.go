@@ -0,0 +1,67 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rivo/tview"
+)
+
+func TestRateLimiterAllowsUpToLimit(t *testing.T) {
+	rl := newRateLimiter(2, time.Minute)
+	if !rl.allow("1.2.3.4") {
+		t.Fatal("first request should be allowed")
+	}
+	if !rl.allow("1.2.3.4") {
+		t.Fatal("second request should be allowed")
+	}
+	if rl.allow("1.2.3.4") {
+		t.Fatal("third request should be denied")
+	}
+}
+
+func TestRateLimiterTracksKeysIndependently(t *testing.T) {
+	rl := newRateLimiter(1, time.Minute)
+	if !rl.allow("1.2.3.4") {
+		t.Fatal("first client's request should be allowed")
+	}
+	if !rl.allow("5.6.7.8") {
+		t.Fatal("second client should have its own budget")
+	}
+}
+
+func TestRateLimiterForgetsRequestsOutsideWindow(t *testing.T) {
+	rl := newRateLimiter(1, time.Minute)
+	rl.requests["1.2.3.4"] = []time.Time{time.Now().Add(-2 * time.Minute)}
+
+	if !rl.allow("1.2.3.4") {
+		t.Fatal("expected an aged-out request to free up the limit")
+	}
+}
+
+func TestStripMarkupRemovesKnownTagsOnly(t *testing.T) {
+	in := "[green::b]Title: Example[-::-]\n\n[1][blue::u]link[-::-]"
+	want := "Title: Example\n\n[1]link"
+	if got := stripMarkup(in); got != want {
+		t.Fatalf("stripMarkup() = %q, want %q", got, want)
+	}
+}
+
+// TestHandleRenderRequestUnescapesPlainBody reproduces the --http-server
+// "score: [42[] out of [100[]" bug: plainRenderer escapes literal brackets
+// for the TUI's tag parser to undo, but the headless path never goes
+// through a tview.TextView, so it must undo that escaping itself.
+func TestHandleRenderRequestUnescapesPlainBody(t *testing.T) {
+	tab := NewTab()
+	_, rendered, err := plainRenderer{}.Render(tab, nil, strings.NewReader("score: [42] out of [100]"))
+	if err != nil {
+		t.Fatalf("plainRenderer.Render() error = %v", err)
+	}
+
+	got := tview.Unescape(stripMarkup(rendered))
+	want := "score: [42] out of [100]"
+	if got != want {
+		t.Fatalf("plain body = %q, want %q", got, want)
+	}
+}